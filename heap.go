@@ -0,0 +1,34 @@
+package lru
+
+// expiryHeap is a container/heap min-heap of *Element ordered by
+// expiresAt, used by deleteExpired to find the next entry to purge in
+// O(log n) instead of scanning every key.
+type expiryHeap[KT GKT, VT GVT] []*Element[KT, VT]
+
+func (h expiryHeap[KT, VT]) Len() int { return len(h) }
+
+func (h expiryHeap[KT, VT]) Less(i, j int) bool {
+	return h[i].Value.expiresAt.Before(h[j].Value.expiresAt)
+}
+
+func (h expiryHeap[KT, VT]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].Value.heapIndex = i
+	h[j].Value.heapIndex = j
+}
+
+func (h *expiryHeap[KT, VT]) Push(x any) {
+	ent := x.(*Element[KT, VT])
+	ent.Value.heapIndex = len(*h)
+	*h = append(*h, ent)
+}
+
+func (h *expiryHeap[KT, VT]) Pop() any {
+	old := *h
+	n := len(old)
+	ent := old[n-1]
+	old[n-1] = nil
+	ent.Value.heapIndex = -1
+	*h = old[:n-1]
+	return ent
+}
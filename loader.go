@@ -0,0 +1,63 @@
+package lru
+
+import (
+	"sync"
+	"time"
+)
+
+// call tracks a single in-flight loader invocation so concurrent callers
+// for the same missing key can coalesce onto it, singleflight-style.
+type call[VT GVT] struct {
+	wg  sync.WaitGroup
+	val VT
+	err error
+}
+
+// GetOrLoad returns the cached value for key if present. On a miss, it
+// calls loader exactly once per key even under concurrent access:
+// concurrent callers for the same missing key block on the one in-flight
+// call instead of all invoking loader themselves. The TTL loader returns
+// is passed to AddWithTTL (so 0 means "use the cache's default TTL"). On
+// a loader error nothing is cached by default; with WithNegativeCacheTTL
+// the value loader returned is cached for that TTL, but the error is not,
+// so later hits return that value with a nil error.
+func (c *Cache[KT, VT]) GetOrLoad(key KT, loader func(KT) (VT, time.Duration, error)) (VT, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	c.inflightMu.Lock()
+	if inF, ok := c.inflight[key]; ok {
+		c.inflightMu.Unlock()
+		inF.wg.Wait()
+		return inF.val, inF.err
+	}
+	inF := new(call[VT])
+	inF.wg.Add(1)
+	if c.inflight == nil {
+		c.inflight = map[KT]*call[VT]{}
+	}
+	c.inflight[key] = inF
+	c.inflightMu.Unlock()
+
+	// Release waiters and drop the inflight entry even if loader panics,
+	// so a panicking loader doesn't wedge the key for every later caller.
+	defer func() {
+		inF.wg.Done()
+		c.inflightMu.Lock()
+		delete(c.inflight, key)
+		c.inflightMu.Unlock()
+	}()
+
+	val, ttl, err := loader(key)
+	inF.val, inF.err = val, err
+
+	if err != nil {
+		if c.negativeTTL > 0 {
+			c.AddWithTTL(key, val, c.negativeTTL)
+		}
+		return val, err
+	}
+	c.AddWithTTL(key, val, ttl)
+	return val, nil
+}
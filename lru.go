@@ -7,7 +7,9 @@
 package lru
 
 import (
+	"container/heap"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -22,9 +24,28 @@ type Cache[KT GKT, VT GVT] struct {
 	done       chan struct{}
 	onEvicted  func(key KT, value VT)
 
+	policy EvictionPolicy
+	hand   *Element[KT, VT]
+	lrc    bool
+	clock  func() time.Time
+
+	negativeTTL time.Duration
+	inflightMu  sync.Mutex
+	inflight    map[KT]*call[VT]
+
+	codec Codec[KT, VT]
+
+	hits             atomic.Uint64
+	misses           atomic.Uint64
+	insertions       atomic.Uint64
+	evictionsSize    atomic.Uint64
+	evictionsExpired atomic.Uint64
+
 	sync.Mutex
 	items     map[KT]*Element[KT, VT]
 	evictList *List[KT, VT]
+	expHeap   expiryHeap[KT, VT]
+	timer     *time.Timer
 }
 
 // expirableEntry is used to hold a value in the evictList
@@ -32,6 +53,10 @@ type expirableEntry[KT GKT, VT GVT] struct {
 	key       KT
 	value     VT
 	expiresAt time.Time
+	// heapIndex is this entry's position in expHeap, or -1 once removed.
+	heapIndex int
+	// visited is the SIEVE "has been accessed" bit; unused under PolicyLRU.
+	visited bool
 }
 
 // EvictCallback is used to get a callback when a cache entry is evicted
@@ -39,7 +64,7 @@ type expirableEntry[KT GKT, VT GVT] struct {
 // noEvictionTTL - very long ttl to prevent eviction
 const noEvictionTTL = time.Hour * 24 * 365 * 10
 
-// NewExpirableLRU returns a new cache with expirable entries.
+// NewLRU returns a new cache with expirable entries, configured via opts.
 //
 // Size parameter set to 0 makes cache of unlimited size.
 //
@@ -47,22 +72,23 @@ const noEvictionTTL = time.Hour * 24 * 365 * 10
 //
 // Activates deleteExpired by purgeEvery duration.
 // If MaxKeys and TTL are defined and PurgeEvery is zero, PurgeEvery will be set to 5 minutes.
-func NewLRU[KT GKT, VT GVT](size int, onEvict func(key KT, value VT), ttl, purgeEvery time.Duration) *Cache[KT, VT] {
-	if size < 0 {
-		size = 0
+func NewLRU[KT GKT, VT GVT](opts ...Option[KT, VT]) *Cache[KT, VT] {
+	res := &Cache[KT, VT]{
+		items:     map[KT]*Element[KT, VT]{},
+		evictList: NewList[KT, VT](),
+		expHeap:   make(expiryHeap[KT, VT], 0),
+		done:      make(chan struct{}),
+		clock:     time.Now,
 	}
-	if ttl <= 0 {
-		ttl = noEvictionTTL
+	for _, opt := range opts {
+		opt(res)
 	}
 
-	res := Cache[KT, VT]{
-		items:      map[KT]*Element[KT, VT]{},
-		evictList:  NewList[KT, VT](),
-		ttl:        ttl,
-		purgeEvery: purgeEvery,
-		size:       size,
-		onEvicted:  onEvict,
-		done:       make(chan struct{}),
+	if res.size < 0 {
+		res.size = 0
+	}
+	if res.ttl <= 0 {
+		res.ttl = noEvictionTTL
 	}
 
 	// enable deleteExpired() running in separate goroutine for cache
@@ -71,21 +97,52 @@ func NewLRU[KT GKT, VT GVT](size int, onEvict func(key KT, value VT), ttl, purge
 		if res.purgeEvery <= 0 {
 			res.purgeEvery = time.Minute * 5 // non-zero purge enforced because size defined
 		}
-		go func(done <-chan struct{}) {
-			ticker := time.NewTicker(res.purgeEvery)
-			for {
-				select {
-				case <-done:
-					return
-				case <-ticker.C:
-					res.Lock()
-					res.deleteExpired()
-					res.Unlock()
-				}
+		res.timer = time.AfterFunc(res.purgeEvery, res.purgeAndReschedule)
+	}
+	return res
+}
+
+// NewExpirableLRU returns a new cache with expirable entries, using the
+// pre-Option positional constructor signature. It is kept for callers that
+// haven't migrated to NewLRU's functional options yet.
+func NewExpirableLRU[KT GKT, VT GVT](size int, onEvict func(key KT, value VT), ttl, purgeEvery time.Duration) *Cache[KT, VT] {
+	return NewLRU[KT, VT](
+		WithSize[KT, VT](size),
+		WithOnEvict(onEvict),
+		WithTTL[KT, VT](ttl),
+		WithPurgeEvery[KT, VT](purgeEvery),
+	)
+}
+
+// purgeAndReschedule runs a purge tick and schedules the next one based on
+// the soonest expiry still in the cache, so purgeEvery becomes an upper
+// bound on the wake-up interval instead of the sole driver.
+func (c *Cache[KT, VT]) purgeAndReschedule() {
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+	c.Lock()
+	c.deleteExpired()
+	delay := c.nextPurgeDelay()
+	c.Unlock()
+	c.timer.Reset(delay)
+}
+
+// nextPurgeDelay returns how long to wait before the next purge tick: the
+// time until the soonest expiry, capped at purgeEvery. Has to be called
+// with lock!
+func (c *Cache[KT, VT]) nextPurgeDelay() time.Duration {
+	if len(c.expHeap) > 0 {
+		if d := c.expHeap[0].Value.expiresAt.Sub(c.clock()); d < c.purgeEvery {
+			if d < 0 {
+				return 0
 			}
-		}(res.done)
+			return d
+		}
 	}
-	return &res
+	return c.purgeEvery
 }
 
 // Add adds a key and a value to the LRU interface
@@ -93,8 +150,14 @@ func (c *Cache[KT, VT]) Add(key KT, value VT) (evicted bool) {
 	return c.add(key, value, c.ttl)
 }
 
-// AddWithTTL adds a key and a value with a TTL to the LRU interface
+// AddWithTTL adds a key and a value with a TTL to the LRU interface.
+// A ttl of 0 means "use the cache's default TTL" rather than "expire
+// immediately" - pass a negative duration if an already-expired entry is
+// genuinely what's wanted.
 func (c *Cache[KT, VT]) AddWithTTL(key KT, value VT, ttl time.Duration) (evicted bool) {
+	if ttl == 0 {
+		ttl = c.ttl
+	}
 	return c.add(key, value, ttl)
 }
 
@@ -102,24 +165,29 @@ func (c *Cache[KT, VT]) AddWithTTL(key KT, value VT, ttl time.Duration) (evicted
 func (c *Cache[KT, VT]) add(key KT, value VT, ttl time.Duration) (evicted bool) {
 	c.Lock()
 	defer c.Unlock()
-	now := time.Now()
+	c.deleteExpired()
+	now := c.clock()
 
 	// Check for existing item
 	if ent, ok := c.items[key]; ok {
 		c.evictList.MoveToFront(ent)
 		ent.Value.value = value
 		ent.Value.expiresAt = now.Add(ttl)
+		heap.Fix(&c.expHeap, ent.Value.heapIndex)
 		return false
 	}
 
+	c.insertions.Add(1)
+
 	// Add new item
-	ent := &expirableEntry[KT, VT]{key: key, value: value, expiresAt: now.Add(ttl)}
+	ent := &expirableEntry[KT, VT]{key: key, value: value, expiresAt: now.Add(ttl), heapIndex: -1}
 	entry := c.evictList.PushFront(ent)
 	c.items[key] = entry
+	heap.Push(&c.expHeap, entry)
 
 	// Verify size not exceeded
 	if c.size > 0 && len(c.items) > c.size {
-		c.removeOldest()
+		c.evict()
 		return true
 	}
 	return false
@@ -129,14 +197,22 @@ func (c *Cache[KT, VT]) add(key KT, value VT, ttl time.Duration) (evicted bool)
 func (c *Cache[KT, VT]) Get(key KT) (VT, bool) {
 	c.Lock()
 	defer c.Unlock()
+	c.deleteExpired()
 	if ent, ok := c.items[key]; ok {
 		// Expired item check
-		if time.Now().After(ent.Value.expiresAt) {
+		if c.clock().After(ent.Value.expiresAt) {
+			c.misses.Add(1)
 			return *new(VT), false
 		}
-		c.evictList.MoveToFront(ent)
+		if c.policy == PolicySIEVE {
+			ent.Value.visited = true
+		} else if !c.lrc {
+			c.evictList.MoveToFront(ent)
+		}
+		c.hits.Add(1)
 		return ent.Value.value, true
 	}
+	c.misses.Add(1)
 	return *new(VT), false
 }
 
@@ -146,11 +222,14 @@ func (c *Cache[KT, VT]) Peek(key KT) (VT, bool) {
 	defer c.Unlock()
 	if ent, ok := c.items[key]; ok {
 		// Expired item check
-		if time.Now().After(ent.Value.expiresAt) {
+		if c.clock().After(ent.Value.expiresAt) {
+			c.misses.Add(1)
 			return *new(VT), false
 		}
+		c.hits.Add(1)
 		return ent.Value.value, true
 	}
+	c.misses.Add(1)
 	return *new(VT), false
 }
 
@@ -217,6 +296,8 @@ func (c *Cache[KT, VT]) Purge() {
 		delete(c.items, k)
 	}
 	c.evictList.Init()
+	c.expHeap = c.expHeap[:0]
+	c.hand = nil
 }
 
 // DeleteExpired clears cache of expired items
@@ -245,7 +326,7 @@ func (c *Cache[KT, VT]) Resize(size int) (evicted int) {
 		diff = 0
 	}
 	for i := 0; i < diff; i++ {
-		c.removeOldest()
+		c.evict()
 	}
 	c.size = size
 	return diff
@@ -256,6 +337,22 @@ func (c *Cache[KT, VT]) Close() {
 	c.Lock()
 	defer c.Unlock()
 	close(c.done)
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+}
+
+// GetExpiration returns the expiration time of key, without updating the
+// "recently used"-ness of the key. The bool return is false if key is not
+// present or has already expired.
+func (c *Cache[KT, VT]) GetExpiration(key KT) (time.Time, bool) {
+	c.Lock()
+	defer c.Unlock()
+	ent, ok := c.items[key]
+	if !ok || c.clock().After(ent.Value.expiresAt) {
+		return time.Time{}, false
+	}
+	return ent.Value.expiresAt, true
 }
 
 // removeOldest removes the oldest item from the cache. Has to be called with lock!
@@ -263,6 +360,7 @@ func (c *Cache[KT, VT]) removeOldest() {
 	ent := c.evictList.Back()
 	if ent != nil {
 		c.removeElement(ent)
+		c.evictionsSize.Add(1)
 	}
 }
 
@@ -275,22 +373,43 @@ func (c *Cache[KT, VT]) keys() []KT {
 	return keys
 }
 
-// removeElement is used to remove a given list element from the cache. Has to be called with lock!
+// removeElement is used to remove a given list element from the cache. If
+// e is the SIEVE hand, the hand is advanced to its predecessor (wrapping
+// to Back, or nil if e was the only entry) so it never dangles on a
+// removed element. Has to be called with lock!
 func (c *Cache[KT, VT]) removeElement(e *Element[KT, VT]) {
+	if e == c.hand {
+		next := e.Prev()
+		if next == nil {
+			next = c.evictList.Back()
+		}
+		if next == e {
+			next = nil
+		}
+		c.hand = next
+	}
 	c.evictList.Remove(e)
 	kv := e.Value
 	delete(c.items, kv.key)
+	if kv.heapIndex >= 0 {
+		heap.Remove(&c.expHeap, kv.heapIndex)
+	}
 	if c.onEvicted != nil {
 		c.onEvicted(kv.key, kv.value)
 	}
 }
 
-// deleteExpired deletes expired records. Has to be called with lock!
+// deleteExpired pops entries off expHeap while its root has already expired,
+// so the cost is proportional to the number of expired entries rather than
+// the size of the cache. Has to be called with lock!
 func (c *Cache[KT, VT]) deleteExpired() {
-	for _, key := range c.keys() {
-		if time.Now().After(c.items[key].Value.expiresAt) {
-			c.removeElement(c.items[key])
-			continue
+	now := c.clock()
+	for len(c.expHeap) > 0 {
+		root := c.expHeap[0]
+		if root.Value.expiresAt.After(now) {
+			break
 		}
+		c.removeElement(root)
+		c.evictionsExpired.Add(1)
 	}
 }
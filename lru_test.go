@@ -0,0 +1,222 @@
+package lru
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAddWithTTLExpiry(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	c := NewLRU[string, int](WithClock[string, int](clock))
+
+	c.AddWithTTL("a", 1, time.Minute)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) after expiry = ok; want miss")
+	}
+}
+
+func TestAddWithTTLZeroUsesDefault(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	c := NewLRU[string, int](WithClock[string, int](clock), WithTTL[string, int](time.Minute))
+
+	c.AddWithTTL("a", 1, 0)
+	exp, ok := c.GetExpiration("a")
+	if !ok {
+		t.Fatal("GetExpiration(a) = not ok; want ok")
+	}
+	if want := now.Add(time.Minute); !exp.Equal(want) {
+		t.Fatalf("GetExpiration(a) = %v; want %v", exp, want)
+	}
+}
+
+func TestDeleteExpiredViaHeap(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	c := NewLRU[string, int](WithClock[string, int](clock))
+
+	c.AddWithTTL("a", 1, time.Minute)
+	c.AddWithTTL("b", 2, 2*time.Minute)
+	c.AddWithTTL("c", 3, 3*time.Minute)
+
+	now = now.Add(90 * time.Second)
+	c.DeleteExpired()
+
+	if c.Contains("a") {
+		t.Fatal("a should have been purged by DeleteExpired")
+	}
+	if !c.Contains("b") || !c.Contains("c") {
+		t.Fatal("b and c should still be present")
+	}
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len() = %d; want 2", got)
+	}
+}
+
+func TestSIEVEEvictsUnvisitedFirst(t *testing.T) {
+	c := NewSIEVE[string, int](WithSize[string, int](2))
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Get("a") // mark a as visited
+
+	c.Add("c", 3) // over size: b is unvisited, should be evicted, not a
+
+	if c.Contains("b") {
+		t.Fatal("b should have been evicted under SIEVE")
+	}
+	if !c.Contains("a") || !c.Contains("c") {
+		t.Fatal("a and c should still be present")
+	}
+}
+
+func TestSIEVEHandSurvivesPurge(t *testing.T) {
+	c := NewSIEVE[string, int](WithSize[string, int](2))
+
+	c.Add("a", 1)
+	c.Add("b", 2)
+	c.Get("a")
+	c.Add("c", 3) // evicts b, advances the hand
+
+	c.Purge()
+
+	c.Add("x", 1)
+	c.Add("y", 2)
+	c.Add("z", 3) // over size again: must not panic or corrupt the list
+
+	if got := c.Len(); got != 2 {
+		t.Fatalf("Len() = %d; want 2", got)
+	}
+	if len(c.Keys()) != c.Len() {
+		t.Fatalf("Keys() = %v has different length than Len() = %d", c.Keys(), c.Len())
+	}
+}
+
+func TestStatsCountsHitsMissesAndInsertions(t *testing.T) {
+	c := NewLRU[string, int](WithSize[string, int](1))
+
+	c.Add("a", 1)
+	c.Get("a")   // hit
+	c.Get("b")   // miss
+	c.Add("a", 2) // update, not a new insertion
+	c.Add("b", 3) // new insertion, evicts a for being over size
+
+	stats := c.Stats()
+	if stats.Hits != 1 {
+		t.Errorf("Hits = %d; want 1", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Errorf("Misses = %d; want 1", stats.Misses)
+	}
+	if stats.Insertions != 2 {
+		t.Errorf("Insertions = %d; want 2 (update must not count as an insertion)", stats.Insertions)
+	}
+	if stats.EvictionsSize != 1 {
+		t.Errorf("EvictionsSize = %d; want 1", stats.EvictionsSize)
+	}
+}
+
+func TestResetStats(t *testing.T) {
+	c := NewLRU[string, int]()
+	c.Add("a", 1)
+	c.Get("a")
+	c.ResetStats()
+
+	stats := c.Stats()
+	if stats.Hits != 0 || stats.Misses != 0 || stats.Insertions != 0 {
+		t.Fatalf("Stats() after ResetStats = %+v; want all zero counters", stats)
+	}
+	if stats.Len != 1 {
+		t.Fatalf("Stats().Len = %d; want 1 (ResetStats must not clear contents)", stats.Len)
+	}
+}
+
+func TestGetOrLoadCoalescesConcurrentCallers(t *testing.T) {
+	c := NewLRU[string, int]()
+	var loads atomic.Int64
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := c.GetOrLoad("k", func(string) (int, time.Duration, error) {
+				loads.Add(1)
+				<-release
+				return 42, 0, nil
+			})
+			if err != nil {
+				t.Errorf("GetOrLoad() error = %v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	time.Sleep(10 * time.Millisecond) // let every goroutine reach GetOrLoad
+	close(release)
+	wg.Wait()
+
+	if got := loads.Load(); got != 1 {
+		t.Fatalf("loader invoked %d times; want exactly 1", got)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("results[%d] = %d; want 42", i, v)
+		}
+	}
+}
+
+func TestGetOrLoadPanicDoesNotWedgeKey(t *testing.T) {
+	c := NewLRU[string, int]()
+
+	func() {
+		defer func() { recover() }()
+		c.GetOrLoad("k", func(string) (int, time.Duration, error) {
+			panic("loader blew up")
+		})
+	}()
+
+	v, err := c.GetOrLoad("k", func(string) (int, time.Duration, error) {
+		return 7, 0, nil
+	})
+	if err != nil || v != 7 {
+		t.Fatalf("GetOrLoad() after a panicking loader = %v, %v; want 7, nil", v, err)
+	}
+}
+
+func TestGetOrLoadNegativeCacheTTL(t *testing.T) {
+	wantErr := errors.New("backend down")
+	c := NewLRU[string, int](WithNegativeCacheTTL[string, int](time.Minute))
+
+	var calls int
+	loader := func(string) (int, time.Duration, error) {
+		calls++
+		return 0, 0, wantErr
+	}
+
+	if _, err := c.GetOrLoad("k", loader); !errors.Is(err, wantErr) {
+		t.Fatalf("GetOrLoad() error = %v; want %v", err, wantErr)
+	}
+	// The negative-cache entry holds the value the loader returned (here the
+	// zero value), not the error itself, so a cache hit on it comes back as
+	// (zero value, nil error);
+	// what it buys is suppressing repeat loader calls, not the error.
+	v, err := c.GetOrLoad("k", loader)
+	if err != nil || v != 0 {
+		t.Fatalf("second GetOrLoad() = %v, %v; want 0, nil (served from the negative cache)", v, err)
+	}
+	if calls != 1 {
+		t.Fatalf("loader invoked %d times; want 1 (second call should be served from the negative cache)", calls)
+	}
+}
@@ -0,0 +1,69 @@
+package lru
+
+import "time"
+
+// Option configures a Cache constructed by NewLRU or NewSIEVE.
+type Option[KT GKT, VT GVT] func(*Cache[KT, VT])
+
+// WithSize sets the maximum number of entries in the cache. 0 (the
+// default) means unlimited.
+func WithSize[KT GKT, VT GVT](size int) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.size = size }
+}
+
+// WithTTL sets the cache-wide default TTL used by Add. 0 or negative (the
+// default) turns expiring off.
+func WithTTL[KT GKT, VT GVT](ttl time.Duration) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.ttl = ttl }
+}
+
+// WithPurgeEvery sets the upper bound on how often expired entries are
+// swept from the cache; see NewLRU for how it interacts with size and TTL.
+func WithPurgeEvery[KT GKT, VT GVT](purgeEvery time.Duration) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.purgeEvery = purgeEvery }
+}
+
+// WithOnEvict sets the callback invoked when an entry is evicted or
+// removed from the cache.
+func WithOnEvict[KT GKT, VT GVT](onEvict func(key KT, value VT)) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.onEvicted = onEvict }
+}
+
+// WithEvictionPolicy sets the eviction policy used once the cache is over
+// size. NewSIEVE applies this with PolicySIEVE; most callers of NewLRU can
+// leave it at the default, PolicyLRU.
+func WithEvictionPolicy[KT GKT, VT GVT](policy EvictionPolicy) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.policy = policy }
+}
+
+// WithClock overrides the time source used for expiry and purge
+// scheduling, so tests can advance time deterministically instead of
+// sleeping on the wall clock.
+func WithClock[KT GKT, VT GVT](clock func() time.Time) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.clock = clock }
+}
+
+// WithLRC switches Get to least-recently-created semantics: entries are
+// not moved to the front of the eviction list on a hit. This makes reads
+// cheaper at the cost of evicting by insertion order; an Add that updates
+// an existing key still moves it to the front.
+func WithLRC[KT GKT, VT GVT](lrc bool) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.lrc = lrc }
+}
+
+// WithNegativeCacheTTL makes GetOrLoad, when loader returns an error, store
+// the value loader returned under its key for ttl, so repeat calls within
+// ttl don't invoke loader again. The error itself is not stored: a hit on
+// that entry returns the stored value with a nil error. Without this
+// option nothing is cached on a loader error. Useful to damp a thundering
+// herd against a backend that is failing, not just slow.
+func WithNegativeCacheTTL[KT GKT, VT GVT](ttl time.Duration) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.negativeTTL = ttl }
+}
+
+// WithCodec overrides the format WriteSnapshot/ReadSnapshot (and
+// MarshalBinary/UnmarshalBinary) use to serialize entries. The default is
+// gob; plug in a Codec for JSON, msgpack, or similar.
+func WithCodec[KT GKT, VT GVT](codec Codec[KT, VT]) Option[KT, VT] {
+	return func(c *Cache[KT, VT]) { c.codec = codec }
+}
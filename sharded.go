@@ -0,0 +1,293 @@
+package lru
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"hash/maphash"
+	"io"
+	"runtime"
+	"time"
+)
+
+// Hasher maps a key to a routing hash for ShardedCache. Pass one as the
+// hasher argument to NewShardedCache for key types the default hasher
+// doesn't special-case.
+type Hasher[KT GKT] func(key KT) uint64
+
+// ShardedCache wraps N independent Caches behind a single key-hashed
+// router, so Get/Peek/Contains/Add don't all serialize on one mutex. Its
+// public surface mirrors Cache so it's a drop-in for high-contention
+// workloads; operations that need a single key (Add, Get, Remove, ...) are
+// routed to one shard, and whole-cache operations (Len, Keys, Purge, ...)
+// fan out across all of them.
+type ShardedCache[KT GKT, VT GVT] struct {
+	shards []*Cache[KT, VT]
+	hasher Hasher[KT]
+	seed   maphash.Seed
+}
+
+// NewShardedCache returns a ShardedCache of shards caches, each built via
+// NewLRU with opts. shards is rounded up to the next power of two; 0
+// means runtime.GOMAXPROCS(0). A configured size is split evenly across
+// shards, with the remainder going to the first ones, so the shard sizes
+// sum back to the requested size. A nil hasher uses a built-in one that special-cases
+// string and the fixed-width integer types; pass your own Hasher for
+// other comparable key types.
+func NewShardedCache[KT GKT, VT GVT](shards int, hasher Hasher[KT], opts ...Option[KT, VT]) *ShardedCache[KT, VT] {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	shards = nextPowerOfTwo(shards)
+
+	sc := &ShardedCache[KT, VT]{
+		shards: make([]*Cache[KT, VT], shards),
+		hasher: hasher,
+		seed:   maphash.MakeSeed(),
+	}
+	if sc.hasher == nil {
+		sc.hasher = sc.defaultHash
+	}
+
+	// Probe opts on a throwaway Cache to learn the requested size without
+	// starting its purge goroutine.
+	probe := &Cache[KT, VT]{}
+	for _, opt := range opts {
+		opt(probe)
+	}
+	base, extra := probe.size/shards, probe.size%shards
+
+	for i := range sc.shards {
+		shardSize := base
+		if probe.size > 0 && i < extra {
+			shardSize++
+		}
+		shardOpts := append(append([]Option[KT, VT]{}, opts...), WithSize[KT, VT](shardSize))
+		sc.shards[i] = NewLRU(shardOpts...)
+	}
+	return sc
+}
+
+// nextPowerOfTwo returns the smallest power of two >= n (minimum 1).
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// defaultHash is the built-in Hasher used when NewShardedCache isn't given
+// one: maphash for strings, fnv-1a over the raw bits for fixed-width
+// integers, and fnv-1a over a %v fallback for anything else. There's no
+// []byte case: KT is comparable, and []byte isn't, so it can never be the
+// concrete type held in key.
+func (sc *ShardedCache[KT, VT]) defaultHash(key KT) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		var h maphash.Hash
+		h.SetSeed(sc.seed)
+		h.WriteString(k)
+		return h.Sum64()
+	case int:
+		return fnvUint64(uint64(k))
+	case int8:
+		return fnvUint64(uint64(k))
+	case int16:
+		return fnvUint64(uint64(k))
+	case int32:
+		return fnvUint64(uint64(k))
+	case int64:
+		return fnvUint64(uint64(k))
+	case uint:
+		return fnvUint64(uint64(k))
+	case uint8:
+		return fnvUint64(uint64(k))
+	case uint16:
+		return fnvUint64(uint64(k))
+	case uint32:
+		return fnvUint64(uint64(k))
+	case uint64:
+		return fnvUint64(k)
+	default:
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%v", k)
+		return h.Sum64()
+	}
+}
+
+func fnvUint64(v uint64) uint64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	h.Write(buf[:])
+	return h.Sum64()
+}
+
+// shardFor returns the shard that owns key. Has to be called without
+// holding any shard's lock.
+func (sc *ShardedCache[KT, VT]) shardFor(key KT) *Cache[KT, VT] {
+	return sc.shards[sc.hasher(key)&uint64(len(sc.shards)-1)]
+}
+
+// Add adds a key and a value to the cache.
+func (sc *ShardedCache[KT, VT]) Add(key KT, value VT) (evicted bool) {
+	return sc.shardFor(key).Add(key, value)
+}
+
+// AddWithTTL adds a key and a value with a TTL to the cache.
+func (sc *ShardedCache[KT, VT]) AddWithTTL(key KT, value VT, ttl time.Duration) (evicted bool) {
+	return sc.shardFor(key).AddWithTTL(key, value, ttl)
+}
+
+// Get returns the key value.
+func (sc *ShardedCache[KT, VT]) Get(key KT) (VT, bool) {
+	return sc.shardFor(key).Get(key)
+}
+
+// Peek returns the key value without updating the "recently used"-ness of
+// the key.
+func (sc *ShardedCache[KT, VT]) Peek(key KT) (VT, bool) {
+	return sc.shardFor(key).Peek(key)
+}
+
+// Contains checks if a key is in the cache, without updating the
+// recent-ness or deleting it for being stale.
+func (sc *ShardedCache[KT, VT]) Contains(key KT) bool {
+	return sc.shardFor(key).Contains(key)
+}
+
+// Remove key from the cache.
+func (sc *ShardedCache[KT, VT]) Remove(key KT) bool {
+	return sc.shardFor(key).Remove(key)
+}
+
+// GetOrLoad returns the cached value for key, loading it via loader on a
+// miss; see Cache.GetOrLoad.
+func (sc *ShardedCache[KT, VT]) GetOrLoad(key KT, loader func(KT) (VT, time.Duration, error)) (VT, error) {
+	return sc.shardFor(key).GetOrLoad(key, loader)
+}
+
+// GetExpiration returns the expiration time for key, if present.
+func (sc *ShardedCache[KT, VT]) GetExpiration(key KT) (time.Time, bool) {
+	return sc.shardFor(key).GetExpiration(key)
+}
+
+// Keys returns a slice of the keys in the cache. Unlike Cache.Keys, the
+// result is only ordered within each shard, not across the whole cache.
+func (sc *ShardedCache[KT, VT]) Keys() []KT {
+	keys := make([]KT, 0, sc.Len())
+	for _, shard := range sc.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+	return keys
+}
+
+// WriteSnapshot writes every shard's snapshot to w back to back, in shard
+// order; see Cache.WriteSnapshot.
+func (sc *ShardedCache[KT, VT]) WriteSnapshot(w io.Writer) error {
+	for _, shard := range sc.shards {
+		if err := shard.WriteSnapshot(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadSnapshot restores entries written by WriteSnapshot. It must be
+// called on a ShardedCache with the same shard count and codec as the one
+// that wrote the snapshot, since segments are decoded in the order
+// WriteSnapshot wrote them. Each decoded entry is then routed through
+// shardFor rather than replayed into the shard it was read from, since
+// the two caches can still disagree on shard ownership - e.g. the
+// default string hasher seeds maphash.Seed randomly per instance; see
+// Cache.ReadSnapshot.
+func (sc *ShardedCache[KT, VT]) ReadSnapshot(r io.Reader) error {
+	for _, shard := range sc.shards {
+		entries, err := shard.decodeSnapshot(r)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			target := sc.shardFor(e.Key)
+			now := target.clock()
+			target.Lock()
+			if now.After(e.ExpiresAt) {
+				target.Unlock()
+				continue
+			}
+			target.restoreEntry(e.Key, e.Value, e.ExpiresAt)
+			target.Unlock()
+		}
+	}
+	return nil
+}
+
+// Purge clears every shard completely.
+func (sc *ShardedCache[KT, VT]) Purge() {
+	for _, shard := range sc.shards {
+		shard.Purge()
+	}
+}
+
+// DeleteExpired clears every shard of expired items.
+func (sc *ShardedCache[KT, VT]) DeleteExpired() {
+	for _, shard := range sc.shards {
+		shard.DeleteExpired()
+	}
+}
+
+// Len returns the total count of items across all shards.
+func (sc *ShardedCache[KT, VT]) Len() int {
+	total := 0
+	for _, shard := range sc.shards {
+		total += shard.Len()
+	}
+	return total
+}
+
+// Resize changes the overall cache size, dividing it evenly across
+// shards as NewShardedCache does.
+func (sc *ShardedCache[KT, VT]) Resize(size int) (evicted int) {
+	if size <= 0 {
+		return 0
+	}
+	base, extra := size/len(sc.shards), size%len(sc.shards)
+	for i, shard := range sc.shards {
+		shardSize := base
+		if i < extra {
+			shardSize++
+		}
+		evicted += shard.Resize(shardSize)
+	}
+	return evicted
+}
+
+// Close cleans every shard and destroys their running goroutines.
+func (sc *ShardedCache[KT, VT]) Close() {
+	for _, shard := range sc.shards {
+		shard.Close()
+	}
+}
+
+// Stats returns the sum of every shard's counters.
+func (sc *ShardedCache[KT, VT]) Stats() Stats {
+	var total Stats
+	for _, shard := range sc.shards {
+		s := shard.Stats()
+		total.Hits += s.Hits
+		total.Misses += s.Misses
+		total.Insertions += s.Insertions
+		total.EvictionsSize += s.EvictionsSize
+		total.EvictionsExpired += s.EvictionsExpired
+		total.Len += s.Len
+	}
+	return total
+}
+
+// ResetStats zeroes every shard's counters.
+func (sc *ShardedCache[KT, VT]) ResetStats() {
+	for _, shard := range sc.shards {
+		shard.ResetStats()
+	}
+}
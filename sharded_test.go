@@ -0,0 +1,65 @@
+package lru
+
+import "testing"
+
+func TestShardedCacheRoutesConsistently(t *testing.T) {
+	sc := NewShardedCache[string, int](4, nil)
+
+	sc.Add("a", 1)
+	sc.Add("b", 2)
+	sc.Add("c", 3)
+
+	for _, k := range []string{"a", "b", "c"} {
+		v, ok := sc.Get(k)
+		if !ok {
+			t.Fatalf("Get(%q) = not ok; want ok", k)
+		}
+		want := map[string]int{"a": 1, "b": 2, "c": 3}[k]
+		if v != want {
+			t.Fatalf("Get(%q) = %d; want %d", k, v, want)
+		}
+	}
+	if got := sc.Len(); got != 3 {
+		t.Fatalf("Len() = %d; want 3", got)
+	}
+}
+
+func TestShardedCacheShardsRoundUpToPowerOfTwo(t *testing.T) {
+	sc := NewShardedCache[string, int](3, nil)
+	if got := len(sc.shards); got != 4 {
+		t.Fatalf("len(shards) = %d; want 4 (3 rounded up to next power of two)", got)
+	}
+}
+
+func TestShardedCacheStatsSumAcrossShards(t *testing.T) {
+	sc := NewShardedCache[int, int](4, nil)
+	for i := 0; i < 20; i++ {
+		sc.Add(i, i)
+	}
+	for i := 0; i < 20; i++ {
+		sc.Get(i)
+	}
+	sc.Get(-1) // miss
+
+	stats := sc.Stats()
+	if stats.Insertions != 20 {
+		t.Errorf("Insertions = %d; want 20", stats.Insertions)
+	}
+	if stats.Hits != 20 {
+		t.Errorf("Hits = %d; want 20", stats.Hits)
+	}
+	if stats.Misses != 1 {
+		t.Errorf("Misses = %d; want 1", stats.Misses)
+	}
+}
+
+func TestShardedCachePurgeClearsEveryShard(t *testing.T) {
+	sc := NewShardedCache[int, int](4, nil)
+	for i := 0; i < 20; i++ {
+		sc.Add(i, i)
+	}
+	sc.Purge()
+	if got := sc.Len(); got != 0 {
+		t.Fatalf("Len() after Purge = %d; want 0", got)
+	}
+}
@@ -0,0 +1,65 @@
+package lru
+
+// EvictionPolicy selects how a Cache picks a victim once it's over size.
+// Set it via WithEvictionPolicy.
+type EvictionPolicy int
+
+const (
+	// PolicyLRU evicts the least-recently-used entry (the default).
+	PolicyLRU EvictionPolicy = iota
+	// PolicySIEVE evicts using the SIEVE algorithm: a single visited bit
+	// per entry and a hand that sweeps the list, so a Get only sets the
+	// bit instead of paying for a MoveToFront on every hit.
+	PolicySIEVE
+)
+
+// NewSIEVE returns a new cache with expirable entries that evicts using
+// SIEVE instead of move-to-front LRU. Get marks an entry as visited
+// without reordering the list; eviction walks a hand backward over the
+// list, clearing visited bits, and evicts the first entry it finds whose
+// bit is already false. This tends to produce a higher hit rate than
+// plain LRU on scan-heavy workloads while being simpler than LRU-2/ARC.
+//
+// It is a thin wrapper around NewLRU(append(opts, WithEvictionPolicy(PolicySIEVE))...).
+func NewSIEVE[KT GKT, VT GVT](opts ...Option[KT, VT]) *Cache[KT, VT] {
+	return NewLRU[KT, VT](append(opts, WithEvictionPolicy[KT, VT](PolicySIEVE))...)
+}
+
+// evict removes one entry per the cache's eviction policy. Has to be
+// called with lock!
+func (c *Cache[KT, VT]) evict() {
+	if c.policy == PolicySIEVE {
+		c.evictSieve()
+		return
+	}
+	c.removeOldest()
+}
+
+// evictSieve walks c.hand backward over evictList, clearing visited bits,
+// and removes the first entry whose bit is already false. removeElement
+// advances the hand to its predecessor as part of removing it.
+// Has to be called with lock!
+func (c *Cache[KT, VT]) evictSieve() {
+	hand := c.hand
+	if hand == nil {
+		hand = c.evictList.Back()
+	}
+	for hand != nil && hand.Value.visited {
+		hand.Value.visited = false
+		prev := hand.Prev()
+		if prev == nil {
+			prev = c.evictList.Back()
+		}
+		if prev == hand {
+			break
+		}
+		hand = prev
+	}
+	if hand == nil {
+		return
+	}
+
+	c.hand = hand
+	c.removeElement(hand)
+	c.evictionsSize.Add(1)
+}
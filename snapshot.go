@@ -0,0 +1,134 @@
+package lru
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/gob"
+	"io"
+	"time"
+)
+
+// SnapshotEntry is one (key, value, expiresAt) triple as written by
+// WriteSnapshot, oldest to newest. It's exported so a custom Codec can be
+// implemented outside this package.
+type SnapshotEntry[KT GKT, VT GVT] struct {
+	Key       KT
+	Value     VT
+	ExpiresAt time.Time
+}
+
+// Codec encodes and decodes the entries a Cache snapshots. The default,
+// used unless WithCodec overrides it, is gob.
+type Codec[KT GKT, VT GVT] interface {
+	Encode(w io.Writer, entries []SnapshotEntry[KT, VT]) error
+	Decode(r io.Reader) ([]SnapshotEntry[KT, VT], error)
+}
+
+// gobCodec is the default Codec.
+type gobCodec[KT GKT, VT GVT] struct{}
+
+func (gobCodec[KT, VT]) Encode(w io.Writer, entries []SnapshotEntry[KT, VT]) error {
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+func (gobCodec[KT, VT]) Decode(r io.Reader) ([]SnapshotEntry[KT, VT], error) {
+	var entries []SnapshotEntry[KT, VT]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// codecOrDefault returns c.codec, or gobCodec if none was set via
+// WithCodec. Safe to call without the lock: codec is fixed at
+// construction and never mutated afterward.
+func (c *Cache[KT, VT]) codecOrDefault() Codec[KT, VT] {
+	if c.codec != nil {
+		return c.codec
+	}
+	return gobCodec[KT, VT]{}
+}
+
+// WriteSnapshot writes every non-expired entry to w, oldest to newest, so
+// ReadSnapshot can restore the cache's LRU ordering on the other end.
+func (c *Cache[KT, VT]) WriteSnapshot(w io.Writer) error {
+	c.Lock()
+	now := c.clock()
+	entries := make([]SnapshotEntry[KT, VT], 0, c.evictList.Len())
+	for ent := c.evictList.Back(); ent != nil; ent = ent.Prev() {
+		if now.After(ent.Value.expiresAt) {
+			continue
+		}
+		entries = append(entries, SnapshotEntry[KT, VT]{
+			Key:       ent.Value.key,
+			Value:     ent.Value.value,
+			ExpiresAt: ent.Value.expiresAt,
+		})
+	}
+	codec := c.codecOrDefault()
+	c.Unlock()
+
+	return codec.Encode(w, entries)
+}
+
+// decodeSnapshot reads one WriteSnapshot-encoded segment from r using c's
+// codec, without restoring it.
+func (c *Cache[KT, VT]) decodeSnapshot(r io.Reader) ([]SnapshotEntry[KT, VT], error) {
+	c.Lock()
+	codec := c.codecOrDefault()
+	c.Unlock()
+	return codec.Decode(r)
+}
+
+// ReadSnapshot restores entries written by WriteSnapshot, oldest to
+// newest, so the restored cache's LRU ordering matches the original.
+// Entries already expired by the time they're read are dropped.
+func (c *Cache[KT, VT]) ReadSnapshot(r io.Reader) error {
+	entries, err := c.decodeSnapshot(r)
+	if err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	now := c.clock()
+	for _, e := range entries {
+		if now.After(e.ExpiresAt) {
+			continue
+		}
+		c.restoreEntry(e.Key, e.Value, e.ExpiresAt)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the cache's non-expired entries using the same
+// format as WriteSnapshot.
+func (c *Cache[KT, VT]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := c.WriteSnapshot(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores entries encoded by MarshalBinary.
+func (c *Cache[KT, VT]) UnmarshalBinary(data []byte) error {
+	return c.ReadSnapshot(bytes.NewReader(data))
+}
+
+// restoreEntry pushes a restored (key, value, expiresAt) triple to the
+// front of evictList, as the newest entry seen so far, replacing any
+// existing entry under key. Has to be called with lock!
+func (c *Cache[KT, VT]) restoreEntry(key KT, value VT, expiresAt time.Time) {
+	if ent, ok := c.items[key]; ok {
+		c.removeElement(ent)
+	}
+	ent := &expirableEntry[KT, VT]{key: key, value: value, expiresAt: expiresAt, heapIndex: -1}
+	entry := c.evictList.PushFront(ent)
+	c.items[key] = entry
+	heap.Push(&c.expHeap, entry)
+
+	if c.size > 0 && len(c.items) > c.size {
+		c.evict()
+	}
+}
@@ -0,0 +1,101 @@
+package lru
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestCacheSnapshotRoundTrip(t *testing.T) {
+	src := NewLRU[string, int]()
+	for i, k := range []string{"a", "b", "c"} {
+		src.Add(k, i)
+	}
+
+	var buf bytes.Buffer
+	if err := src.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("WriteSnapshot() error = %v", err)
+	}
+
+	dst := NewLRU[string, int]()
+	if err := dst.ReadSnapshot(&buf); err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+
+	for i, k := range []string{"a", "b", "c"} {
+		v, ok := dst.Get(k)
+		if !ok || v != i {
+			t.Errorf("Get(%q) = %v, %v; want %d, true", k, v, ok, i)
+		}
+	}
+}
+
+func TestCacheSnapshotDropsExpiredEntries(t *testing.T) {
+	now := newTestClock(0)
+	src := NewLRU[string, int](WithClock[string, int](now.Now))
+	src.AddWithTTL("a", 1, -time.Second) // already expired
+	src.AddWithTTL("b", 2, time.Minute)
+
+	now.advance(time.Second)
+
+	var buf bytes.Buffer
+	if err := src.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("WriteSnapshot() error = %v", err)
+	}
+
+	dst := NewLRU[string, int](WithClock[string, int](now.Now))
+	if err := dst.ReadSnapshot(&buf); err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+	if dst.Contains("a") {
+		t.Error(`Contains("a") = true; want false (expired before WriteSnapshot)`)
+	}
+	if !dst.Contains("b") {
+		t.Error(`Contains("b") = false; want true`)
+	}
+}
+
+// TestShardedCacheSnapshotRestoresAcrossDifferentSeeds reproduces the
+// scenario where a ShardedCache is restored into a different instance
+// than the one that wrote the snapshot: since the default string hasher
+// seeds maphash.Seed randomly per instance, a positional shard-to-shard
+// replay would route most string keys to the wrong shard. ReadSnapshot
+// must route every entry through its own shardFor instead.
+func TestShardedCacheSnapshotRestoresAcrossDifferentSeeds(t *testing.T) {
+	src := NewShardedCache[string, int](8, nil)
+	keys := []string{"k0", "k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8", "k9"}
+	for i, k := range keys {
+		src.Add(k, i)
+	}
+
+	var buf bytes.Buffer
+	if err := src.WriteSnapshot(&buf); err != nil {
+		t.Fatalf("WriteSnapshot() error = %v", err)
+	}
+
+	dst := NewShardedCache[string, int](8, nil) // fresh instance, different maphash seed
+	if err := dst.ReadSnapshot(&buf); err != nil {
+		t.Fatalf("ReadSnapshot() error = %v", err)
+	}
+
+	for i, k := range keys {
+		v, ok := dst.Get(k)
+		if !ok || v != i {
+			t.Errorf("Get(%q) = %v, %v; want %d, true", k, v, ok, i)
+		}
+	}
+}
+
+// testClock is a manually-advanceable time source for deterministic
+// expiry tests; see WithClock.
+type testClock struct {
+	now time.Time
+}
+
+func newTestClock(offset time.Duration) *testClock {
+	return &testClock{now: time.Unix(0, 0).Add(offset)}
+}
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func (c *testClock) advance(d time.Duration) { c.now = c.now.Add(d) }
@@ -0,0 +1,39 @@
+package lru
+
+// Stats is a point-in-time snapshot of a Cache's hit/miss/eviction
+// counters, returned by (*Cache).Stats.
+type Stats struct {
+	Hits             uint64
+	Misses           uint64
+	Insertions       uint64
+	EvictionsSize    uint64
+	EvictionsExpired uint64
+	Len              uint64
+}
+
+// Stats returns a snapshot of the cache's counters: hits, misses,
+// insertions, evictions split by cause (size-based vs TTL-based), and
+// the current length. Counters use atomic.Uint64 so they're cheap
+// enough to leave on in production.
+func (c *Cache[KT, VT]) Stats() Stats {
+	c.Lock()
+	defer c.Unlock()
+	return Stats{
+		Hits:             c.hits.Load(),
+		Misses:           c.misses.Load(),
+		Insertions:       c.insertions.Load(),
+		EvictionsSize:    c.evictionsSize.Load(),
+		EvictionsExpired: c.evictionsExpired.Load(),
+		Len:              uint64(c.evictList.Len()),
+	}
+}
+
+// ResetStats zeroes the hit/miss/eviction/insertion counters. It does not
+// affect the cache's contents.
+func (c *Cache[KT, VT]) ResetStats() {
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.insertions.Store(0)
+	c.evictionsSize.Store(0)
+	c.evictionsExpired.Store(0)
+}